@@ -0,0 +1,118 @@
+// Package bytesutil provides byte-handling primitives that are shared
+// across VictoriaMetrics-family projects: a reusable byte buffer backed
+// by bytebufferpool, zero-copy string/byte conversions, buffer resize
+// helpers and caches for string interning.
+package bytesutil
+
+import (
+	"sync/atomic"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// ByteBuffer implements a simple byte buffer with a bytebufferpool-compatible
+// layout, so it can be used as a drop-in replacement in hot paths that
+// need a *ByteBuffer instead of a bytebufferpool.ByteBuffer.
+type ByteBuffer struct {
+	B []byte
+}
+
+// Write implements io.Writer.
+func (bb *ByteBuffer) Write(p []byte) (int, error) {
+	bb.B = append(bb.B, p...)
+	return len(p), nil
+}
+
+// WriteByte writes b to bb.
+func (bb *ByteBuffer) WriteByte(b byte) error {
+	bb.B = append(bb.B, b)
+	return nil
+}
+
+// WriteString writes s to bb.
+func (bb *ByteBuffer) WriteString(s string) (int, error) {
+	bb.B = append(bb.B, s...)
+	return len(s), nil
+}
+
+// Len returns the length of bb.B.
+func (bb *ByteBuffer) Len() int {
+	return len(bb.B)
+}
+
+// Reset resets bb for subsequent reuse.
+func (bb *ByteBuffer) Reset() {
+	bb.B = bb.B[:0]
+}
+
+// maxPooledBufferSize is the maximum capacity of a ByteBuffer that may be
+// returned to a ByteBufferPool. Bigger buffers are discarded instead of
+// being recycled, in order to prevent a handful of unusually large
+// buffers from inflating the pool's steady-state memory usage.
+const maxPooledBufferSize = 1024 * 1024
+
+// ByteBufferPool is a pool of ByteBuffers, backed by bytebufferpool.Pool.
+type ByteBufferPool struct {
+	P bytebufferpool.Pool
+
+	heldBytes atomic.Int64
+	m         atomic.Pointer[poolMetrics]
+}
+
+// Get returns a ByteBuffer from bbp.
+//
+// The returned ByteBuffer must be returned to bbp via Put after it is no
+// longer needed, in order to reduce memory allocations.
+func (bbp *ByteBufferPool) Get() *ByteBuffer {
+	v := bbp.P.Get()
+	// heldBytes is tracked unconditionally, regardless of whether metrics
+	// are registered, so every buffer that was ever credited by Put is
+	// also debited here by exactly the same amount. A fresh buffer handed
+	// out by an empty pool has cap 0, so it never debits bytes it wasn't
+	// credited for. This keeps the gauge correct even when RegisterMetrics
+	// is called mid-lifetime, instead of having to reconcile credited and
+	// uncredited buffers after the fact.
+	subtractClamped(&bbp.heldBytes, int64(cap(v.B)))
+	if m := bbp.m.Load(); m != nil {
+		m.getCalls.Inc()
+	}
+	return &ByteBuffer{
+		B: v.B,
+	}
+}
+
+// Put returns bb to bbp.
+//
+// bb mustn't be used after this call.
+func (bbp *ByteBufferPool) Put(bb *ByteBuffer) {
+	m := bbp.m.Load()
+	if m != nil {
+		m.putCalls.Inc()
+		m.bufferSizes.Update(float64(len(bb.B)))
+	}
+	if cap(bb.B) > maxPooledBufferSize {
+		if m != nil {
+			m.discardedCalls.Inc()
+		}
+		return
+	}
+	bbp.heldBytes.Add(int64(cap(bb.B)))
+	bbp.P.Put(&bytebufferpool.ByteBuffer{
+		B: bb.B,
+	})
+}
+
+// subtractClamped atomically subtracts delta from *a, clamping the result
+// at zero instead of letting it go negative.
+func subtractClamped(a *atomic.Int64, delta int64) {
+	for {
+		old := a.Load()
+		newVal := old - delta
+		if newVal < 0 {
+			newVal = 0
+		}
+		if a.CompareAndSwap(old, newVal) {
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package bytesutil
+
+import (
+	"testing"
+)
+
+func TestByteBufferPool(t *testing.T) {
+	var bbp ByteBufferPool
+
+	bb := bbp.Get()
+	if bb.Len() != 0 {
+		t.Fatalf("unexpected non-empty buffer obtained from the pool")
+	}
+	bb.WriteString("foobar")
+	if bb.Len() != 6 {
+		t.Fatalf("unexpected length; got %d; want 6", bb.Len())
+	}
+	bbp.Put(bb)
+
+	bb2 := bbp.Get()
+	bb2.Reset()
+	if bb2.Len() != 0 {
+		t.Fatalf("unexpected non-empty buffer after Reset")
+	}
+	bbp.Put(bb2)
+}
+
+func BenchmarkByteBufferPool(b *testing.B) {
+	var bbp ByteBufferPool
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			bb := bbp.Get()
+			bb.WriteString("some test string")
+			bbp.Put(bb)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package bytesutil
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/valyala/fastrand"
+)
+
+// maxCacheEntries is the maximum number of entries FastStringMatcher and
+// FastStringTransformer keep cached before they start clearing their
+// internal maps, in order to bound memory usage on unbounded key sets.
+const maxCacheEntries = 100e3
+
+// FastStringTransformer implements fast transformer for strings.
+//
+// It caches transformed strings and returns them on subsequent calls
+// with the same input instead of re-invoking transformFunc, which may
+// be expensive.
+type FastStringTransformer struct {
+	mu sync.RWMutex
+	m  map[string]string
+
+	transformFunc func(s string) string
+}
+
+// NewFastStringTransformer creates a new transformer, which applies
+// transformFunc to strings and caches the results.
+//
+// transformFunc must return the same result for the same input, since
+// the result may be cached indefinitely.
+func NewFastStringTransformer(transformFunc func(s string) string) *FastStringTransformer {
+	return &FastStringTransformer{
+		m:             make(map[string]string),
+		transformFunc: transformFunc,
+	}
+}
+
+// Transform applies fst.transformFunc to s and returns the result,
+// reusing a cached result for s if one is available.
+func (fst *FastStringTransformer) Transform(s string) string {
+	fst.mu.RLock()
+	sTransformed, ok := fst.m[s]
+	fst.mu.RUnlock()
+	if ok {
+		return sTransformed
+	}
+
+	// Slow path - transform s and cache the result.
+	sTransformed = fst.transformFunc(s)
+	sCopy := strings.Clone(s)
+
+	fst.mu.Lock()
+	if len(fst.m) >= maxCacheEntries {
+		// Reset the map occasionally in order to prevent from unbound
+		// growth on high-cardinality inputs. The reset is probabilistic
+		// (1 in 100 inserts once the limit is reached), so the map may
+		// grow somewhat beyond maxCacheEntries before it is cleared.
+		if fastrand.Uint32n(100) == 0 {
+			fst.m = make(map[string]string, maxCacheEntries)
+		}
+	}
+	fst.m[sCopy] = sTransformed
+	fst.mu.Unlock()
+
+	return sTransformed
+}
+
+// FastStringMatcher implements fast matcher for strings.
+//
+// It caches match results and returns them on subsequent calls with the
+// same input instead of re-invoking matchFunc, which may be expensive.
+type FastStringMatcher struct {
+	mu sync.RWMutex
+	m  map[string]bool
+
+	matchFunc func(s string) bool
+}
+
+// NewFastStringMatcher creates a new matcher, which applies matchFunc to
+// strings and caches the results.
+func NewFastStringMatcher(matchFunc func(s string) bool) *FastStringMatcher {
+	return &FastStringMatcher{
+		m:         make(map[string]bool),
+		matchFunc: matchFunc,
+	}
+}
+
+// Match applies fsm.matchFunc to s and returns the result, reusing a
+// cached result for s if one is available.
+func (fsm *FastStringMatcher) Match(s string) bool {
+	fsm.mu.RLock()
+	b, ok := fsm.m[s]
+	fsm.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	// Slow path - match s and cache the result.
+	b = fsm.matchFunc(s)
+	sCopy := strings.Clone(s)
+
+	fsm.mu.Lock()
+	if len(fsm.m) >= maxCacheEntries {
+		if fastrand.Uint32n(100) == 0 {
+			fsm.m = make(map[string]bool, maxCacheEntries)
+		}
+	}
+	fsm.m[sCopy] = b
+	fsm.mu.Unlock()
+
+	return b
+}
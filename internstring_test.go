@@ -0,0 +1,77 @@
+package bytesutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFastStringTransformer(t *testing.T) {
+	calls := 0
+	fst := NewFastStringTransformer(func(s string) string {
+		calls++
+		return s + "_transformed"
+	})
+	for i := 0; i < 10; i++ {
+		result := fst.Transform("foo")
+		if result != "foo_transformed" {
+			t.Fatalf("unexpected result; got %q; want %q", result, "foo_transformed")
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("unexpected number of transformFunc calls; got %d; want 1", calls)
+	}
+
+	result := fst.Transform("bar")
+	if result != "bar_transformed" {
+		t.Fatalf("unexpected result; got %q; want %q", result, "bar_transformed")
+	}
+	if calls != 2 {
+		t.Fatalf("unexpected number of transformFunc calls; got %d; want 2", calls)
+	}
+}
+
+func TestFastStringMatcher(t *testing.T) {
+	calls := 0
+	fsm := NewFastStringMatcher(func(s string) bool {
+		calls++
+		return len(s) > 3
+	})
+	for i := 0; i < 10; i++ {
+		if fsm.Match("foo") {
+			t.Fatalf("unexpected match for %q", "foo")
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("unexpected number of matchFunc calls; got %d; want 1", calls)
+	}
+
+	if !fsm.Match("foobar") {
+		t.Fatalf("unexpected mismatch for %q", "foobar")
+	}
+	if calls != 2 {
+		t.Fatalf("unexpected number of matchFunc calls; got %d; want 2", calls)
+	}
+}
+
+func BenchmarkFastStringMatcher(b *testing.B) {
+	fsm := NewFastStringMatcher(func(s string) bool {
+		return len(s)%2 == 0
+	})
+	ss := make([]string, 100)
+	for i := range ss {
+		ss[i] = fmt.Sprintf("some-string-%d", i)
+	}
+	// Warm up the cache.
+	for _, s := range ss {
+		fsm.Match(s)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			fsm.Match(ss[i%len(ss)])
+			i++
+		}
+	})
+}
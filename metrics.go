@@ -0,0 +1,53 @@
+package bytesutil
+
+import (
+	"github.com/VictoriaMetrics/metrics"
+)
+
+// poolMetrics holds the VictoriaMetrics/metrics objects backing a
+// ByteBufferPool's instrumentation.
+//
+// It is created lazily by RegisterMetrics, so a pool that never calls
+// RegisterMetrics pays no metrics overhead beyond a single nil pointer
+// load per Get/Put. ByteBufferPool.heldBytes itself is tracked
+// unconditionally on every Get/Put, independent of m, so that the gauge
+// built from it is correct no matter when RegisterMetrics is called.
+type poolMetrics struct {
+	getCalls       *metrics.Counter
+	putCalls       *metrics.Counter
+	discardedCalls *metrics.Counter
+	bufferSizes    *metrics.Histogram
+}
+
+// RegisterMetrics registers counters, a histogram and a gauge describing
+// bbp's usage under the given prefix in set:
+//
+//   - <prefix>_get_total - the number of ByteBuffers obtained via Get.
+//   - <prefix>_put_total - the number of ByteBuffers returned via Put.
+//   - <prefix>_discarded_total - the number of oversized buffers dropped
+//     by Put instead of being recycled.
+//   - <prefix>_buffer_size_bytes - a histogram of buffer sizes observed
+//     on Put.
+//   - <prefix>_held_bytes - the approximate number of bytes currently
+//     sitting idle in the pool. This is accurate even for buffers that
+//     cycled through Put before RegisterMetrics was ever called, since
+//     bbp tracks held bytes independently of whether metrics are
+//     registered.
+//
+// RegisterMetrics is optional: bbp behaves identically without calling
+// it, just without the exposed stats. It may be called at any point in
+// bbp's lifetime, including after Get/Put have already been used. It
+// must not be called more than once for the same bbp, since metric names
+// must stay unique within set.
+func (bbp *ByteBufferPool) RegisterMetrics(set *metrics.Set, prefix string) {
+	m := &poolMetrics{
+		getCalls:       set.NewCounter(prefix + `_get_total`),
+		putCalls:       set.NewCounter(prefix + `_put_total`),
+		discardedCalls: set.NewCounter(prefix + `_discarded_total`),
+		bufferSizes:    set.GetOrCreateHistogram(prefix + `_buffer_size_bytes`),
+	}
+	set.NewGauge(prefix+`_held_bytes`, func() float64 {
+		return float64(bbp.heldBytes.Load())
+	})
+	bbp.m.Store(m)
+}
@@ -0,0 +1,86 @@
+package bytesutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/VictoriaMetrics/metrics"
+)
+
+func TestByteBufferPoolRegisterMetrics(t *testing.T) {
+	var bbp ByteBufferPool
+	set := metrics.NewSet()
+	bbp.RegisterMetrics(set, "test_pool")
+
+	bb := bbp.Get()
+	bb.WriteString("foobar")
+	bbp.Put(bb)
+
+	var buf bytes.Buffer
+	set.WritePrometheus(&buf)
+	s := buf.String()
+	for _, metricName := range []string{
+		"test_pool_get_total",
+		"test_pool_put_total",
+		"test_pool_discarded_total",
+		"test_pool_buffer_size_bytes",
+		"test_pool_held_bytes",
+	} {
+		if !strings.Contains(s, metricName) {
+			t.Fatalf("missing metric %q in output:\n%s", metricName, s)
+		}
+	}
+}
+
+func TestByteBufferPoolDiscardsOversizedBuffers(t *testing.T) {
+	var bbp ByteBufferPool
+	set := metrics.NewSet()
+	bbp.RegisterMetrics(set, "oversized_pool")
+
+	bb := &ByteBuffer{
+		B: make([]byte, 0, maxPooledBufferSize+1),
+	}
+	bbp.Put(bb)
+
+	var buf bytes.Buffer
+	set.WritePrometheus(&buf)
+	s := buf.String()
+	if !strings.Contains(s, `oversized_pool_discarded_total 1`) {
+		t.Fatalf("expected oversized_pool_discarded_total to be 1; got:\n%s", s)
+	}
+}
+
+func TestByteBufferPoolHeldBytesSurvivesLateRegistration(t *testing.T) {
+	var bbp ByteBufferPool
+
+	// Two buffers cycle through Put before RegisterMetrics is ever called.
+	bbp.Put(&ByteBuffer{B: make([]byte, 100)})
+	bbp.Put(&ByteBuffer{B: make([]byte, 50)})
+
+	set := metrics.NewSet()
+	bbp.RegisterMetrics(set, "held_pool")
+
+	if got := bbp.heldBytes.Load(); got != 150 {
+		t.Fatalf("unexpected heldBytes right after RegisterMetrics; got %d; want 150", got)
+	}
+
+	// Get reclaims one of the pre-existing buffers now that metrics are on.
+	bb := bbp.Get()
+	reclaimed := int64(cap(bb.B))
+
+	got := bbp.heldBytes.Load()
+	if got < 0 {
+		t.Fatalf("heldBytes must never go negative; got %d", got)
+	}
+	// The other buffer Put before registration is still resident, so
+	// heldBytes must reflect it instead of being clamped to zero.
+	if want := 150 - reclaimed; got != want {
+		t.Fatalf("unexpected heldBytes after Get; got %d; want %d", got, want)
+	}
+
+	bbp.Put(bb)
+	if got, want := bbp.heldBytes.Load(), int64(150); got != want {
+		t.Fatalf("unexpected heldBytes after returning the reclaimed buffer; got %d; want %d", got, want)
+	}
+}
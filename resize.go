@@ -0,0 +1,61 @@
+package bytesutil
+
+import (
+	"unsafe"
+)
+
+// Resize resizes b to n bytes and returns the resized buffer.
+//
+// The first len(b) bytes of the returned buffer are preserved; the rest
+// is left as-is (not zeroed beyond what make() guarantees). If n fits
+// into cap(b), the original slice is reused without allocating.
+func Resize(b []byte, n int) []byte {
+	if n <= cap(b) {
+		return b[:n]
+	}
+	nSize := roundToNearestPow2(n)
+	bNew := make([]byte, nSize)
+	copy(bNew, b)
+	return bNew[:n]
+}
+
+// ResizeNoCopy resizes b to n bytes and returns the resized buffer.
+//
+// Unlike Resize, the contents of b aren't preserved when a new
+// allocation is needed - use this when the caller is about to overwrite
+// the whole buffer anyway, since skipping the copy is cheaper.
+func ResizeNoCopy(b []byte, n int) []byte {
+	if n <= cap(b) {
+		return b[:n]
+	}
+	nSize := roundToNearestPow2(n)
+	return make([]byte, nSize)[:n]
+}
+
+// roundToNearestPow2 returns the smallest power of 2 that is >= n.
+//
+// Rounding up amortizes future Resize/ResizeNoCopy calls on the same
+// buffer, since growing buffers tend to grow repeatedly.
+func roundToNearestPow2(n int) int {
+	pow2 := uint(0)
+	for (1 << pow2) < n {
+		pow2++
+	}
+	return 1 << pow2
+}
+
+// ToUnsafeString converts b to a string without memory allocations.
+//
+// The returned string is valid only as long as b isn't modified, since
+// the conversion doesn't copy the underlying bytes.
+func ToUnsafeString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// ToUnsafeBytes converts s to a byte slice without memory allocations.
+//
+// The returned slice must not be modified, since s may be a read-only
+// string literal backed by non-writable memory.
+func ToUnsafeBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
@@ -0,0 +1,91 @@
+package bytesutil
+
+import (
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	f := func(b []byte, n int) {
+		t.Helper()
+		bCopy := append([]byte{}, b...)
+		result := Resize(bCopy, n)
+		if len(result) != n {
+			t.Fatalf("unexpected length; got %d; want %d", len(result), n)
+		}
+		m := len(b)
+		if m > n {
+			m = n
+		}
+		for i := 0; i < m; i++ {
+			if result[i] != b[i] {
+				t.Fatalf("unexpected byte at position %d; got %d; want %d", i, result[i], b[i])
+			}
+		}
+	}
+	f(nil, 0)
+	f(nil, 10)
+	f([]byte("foo"), 0)
+	f([]byte("foo"), 2)
+	f([]byte("foo"), 3)
+	f([]byte("foo"), 10)
+}
+
+func TestResizeNoCopy(t *testing.T) {
+	f := func(b []byte, n int) {
+		t.Helper()
+		result := ResizeNoCopy(b, n)
+		if len(result) != n {
+			t.Fatalf("unexpected length; got %d; want %d", len(result), n)
+		}
+	}
+	f(nil, 0)
+	f(nil, 10)
+	f([]byte("foo"), 0)
+	f([]byte("foo"), 2)
+	f([]byte("foo"), 3)
+	f([]byte("foo"), 10)
+}
+
+func TestToUnsafeStringBytes(t *testing.T) {
+	s := "foobar"
+	b := ToUnsafeBytes(s)
+	if string(b) != s {
+		t.Fatalf("unexpected bytes; got %q; want %q", b, s)
+	}
+	s2 := ToUnsafeString(b)
+	if s2 != s {
+		t.Fatalf("unexpected string; got %q; want %q", s2, s)
+	}
+}
+
+func BenchmarkResize(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 16)
+	for i := 0; i < b.N; i++ {
+		buf = Resize(buf[:0], 1024)
+	}
+}
+
+func BenchmarkResizeNoCopy(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 16)
+	for i := 0; i < b.N; i++ {
+		buf = ResizeNoCopy(buf[:0], 1024)
+	}
+}
+
+func BenchmarkResizeGrowing(b *testing.B) {
+	b.ReportAllocs()
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = Resize(buf, i%4096)
+	}
+}
+
+func BenchmarkResizeNoCopyGrowing(b *testing.B) {
+	b.ReportAllocs()
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = ResizeNoCopy(buf, i%4096)
+	}
+}